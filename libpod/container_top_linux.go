@@ -6,6 +6,7 @@ package libpod
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/rootless"
@@ -23,6 +25,17 @@ import (
 	"golang.org/x/sys/unix"
 )
 
+// TopFrame is a single snapshot of container top output, as produced on a
+// ticker by TopStream.
+type TopFrame struct {
+	// Time the frame was captured.
+	Time time.Time
+	// Headers are the ps(1)-style column headers for Processes.
+	Headers []string
+	// Processes holds one row of fields per running process.
+	Processes [][]string
+}
+
 // Top gathers statistics about the running processes in a container. It returns a
 // []string for output
 func (c *Container) Top(descriptors []string) ([]string, error) {
@@ -100,22 +113,108 @@ func (c *Container) Top(descriptors []string) ([]string, error) {
 //
 // For more details, please refer to github.com/containers/psgo.
 func (c *Container) GetContainerPidInformation(descriptors []string) ([]string, error) {
+	rows, err := c.getContainerPidInformationRows(descriptors)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, strings.Join(row, "\t"))
+	}
+	return res, nil
+}
+
+// getContainerPidInformationRows is the unjoined counterpart of
+// GetContainerPidInformation, returning the raw psgo rows (header row
+// first) so callers such as TopStream can filter or sort columns without
+// re-splitting tabs.
+func (c *Container) getContainerPidInformationRows(descriptors []string) ([][]string, error) {
 	pid := strconv.Itoa(c.state.PID)
-	// NOTE: psgo returns a [][]string to give users the ability to apply
-	//       filters on the data.  We need to change the API here
-	//       to return a [][]string if we want to make use of
-	//       filtering.
 	opts := psgo.JoinNamespaceOpts{FillMappings: rootless.IsRootless()}
+	return psgo.JoinNamespaceAndProcessInfoWithOptions(pid, descriptors, &opts)
+}
 
-	psgoOutput, err := psgo.JoinNamespaceAndProcessInfoWithOptions(pid, descriptors, &opts)
+// TopStream streams successive TopFrame snapshots on the returned channel
+// every interval, re-running GetContainerPidInformation (falling back to
+// execPS when psgo reports an unknown descriptor) inside the container's
+// mount+PID namespace until ctx is canceled. Unlike Top, descriptors are
+// passed on as given, without comma or shell splitting, and the ps(1)
+// command's own line is not filtered out of the fallback rows. The channel is closed when streaming stops, either
+// because ctx was canceled or because an error occurred; callers interested
+// in the latter should log as frames stop arriving, since there is no
+// separate error channel.
+//
+// TopStream is a libpod-layer primitive only: this tree has no pkg/api, so
+// there is no streaming /containers/{id}/top REST handler dispatching to it
+// yet. A bindings/handler pair wiring it up to the API server is follow-up
+// work, not included here.
+func (c *Container) TopStream(ctx context.Context, descriptors []string, interval time.Duration) (<-chan TopFrame, error) {
+	if c.config.NoCgroups {
+		return nil, fmt.Errorf("cannot run top on container %s as it did not create a cgroup: %w", c.ID(), define.ErrNoCgroups)
+	}
+
+	conStat, err := c.State()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to look up state for %s: %w", c.ID(), err)
+	}
+	if conStat != define.ContainerStateRunning {
+		return nil, errors.New("top can only be used on running containers")
 	}
-	res := []string{}
-	for _, out := range psgoOutput {
-		res = append(res, strings.Join(out, "\t"))
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid interval %s: must be greater than 0", interval)
 	}
-	return res, nil
+
+	frames := make(chan TopFrame)
+	go func() {
+		defer close(frames)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			rows, err := c.getContainerPidInformationRows(descriptors)
+			if err != nil {
+				if !errors.Is(err, psgo.ErrUnknownDescriptor) {
+					logrus.Errorf("Streaming top for container %s: %v", c.ID(), err)
+					return
+				}
+				output, err := c.execPS(descriptors)
+				if err != nil {
+					logrus.Errorf("Streaming top for container %s: %v", c.ID(), err)
+					return
+				}
+				rows = splitPSRows(output)
+			}
+
+			if len(rows) > 0 {
+				frame := TopFrame{Time: time.Now(), Headers: rows[0], Processes: rows[1:]}
+				select {
+				case frames <- frame:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return frames, nil
+}
+
+// splitPSRows turns execPS's whitespace-separated ps(1) output lines back
+// into per-field rows, the same shape getContainerPidInformationRows
+// already returns.
+func splitPSRows(lines []string) [][]string {
+	rows := make([][]string, 0, len(lines))
+	for _, line := range lines {
+		rows = append(rows, strings.Fields(line))
+	}
+	return rows
 }
 
 // execute ps(1) from the host within the container mountns
@@ -0,0 +1,41 @@
+//go:build linux
+// +build linux
+
+package libpod
+
+import "testing"
+
+func TestSplitPSRows(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  [][]string
+	}{
+		{"empty", nil, [][]string{}},
+		{"single row", []string{"PID TTY TIME CMD"}, [][]string{{"PID", "TTY", "TIME", "CMD"}}},
+		{
+			"multiple rows with extra whitespace",
+			[]string{"PID   TTY          TIME CMD", "1     pts/0    00:00:00 bash"},
+			[][]string{{"PID", "TTY", "TIME", "CMD"}, {"1", "pts/0", "00:00:00", "bash"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitPSRows(tt.lines)
+			if len(got) != len(tt.want) {
+				t.Fatalf("splitPSRows(%v) = %v, want %v", tt.lines, got, tt.want)
+			}
+			for i := range got {
+				if len(got[i]) != len(tt.want[i]) {
+					t.Fatalf("splitPSRows(%v)[%d] = %v, want %v", tt.lines, i, got[i], tt.want[i])
+				}
+				for j := range got[i] {
+					if got[i][j] != tt.want[i][j] {
+						t.Errorf("splitPSRows(%v)[%d][%d] = %q, want %q", tt.lines, i, j, got[i][j], tt.want[i][j])
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containers/podman/v4/pkg/bindings"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+)
+
+// Pull fetches ref (e.g. "quay.io/baude/artifact:single") from a registry
+// and stores it locally under name. If options.MaxRetries/RetryDelay are
+// set, DoRequest retries the pull request itself with that policy instead
+// of the connection's default.
+func Pull(ctx context.Context, name, ref string, options *PullOptions) (*entities.ArtifactPullReport, error) {
+	if options == nil {
+		options = new(PullOptions)
+	}
+
+	if options.Changed("MaxRetries") || options.Changed("RetryDelay") {
+		conn, err := bindings.GetClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		policy, err := pullRetryPolicy(conn, options)
+		if err != nil {
+			return nil, err
+		}
+		ctx, err = bindings.WithRetryPolicy(ctx, policy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("reference", ref)
+
+	response, err := conn.DoRequest(nil, http.MethodPost, "/artifacts/%s/pull", params, nil, name)
+	if err != nil {
+		return nil, err
+	}
+
+	report := new(entities.ArtifactPullReport)
+	return report, response.Process(report)
+}
+
+// Push uploads the artifact registered under name to ref.
+func Push(ctx context.Context, name, ref string, options *PushOptions) (*entities.ArtifactPushReport, error) {
+	if options == nil {
+		options = new(PushOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("destination", ref)
+
+	response, err := conn.DoRequest(nil, http.MethodPost, "/artifacts/%s/push", params, nil, name)
+	if err != nil {
+		return nil, err
+	}
+
+	report := new(entities.ArtifactPushReport)
+	return report, response.Process(report)
+}
+
+// Add registers a local artifact under name out of the given files, each
+// uploaded as its own blob/layer.
+func Add(ctx context.Context, name string, files []string, options *AddOptions) (*entities.ArtifactAddReport, error) {
+	if options == nil {
+		options = new(AddOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+
+	body, contentType, err := multipartFiles(files)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(body.Name())
+	defer body.Close()
+
+	header := map[string]string{"Content-Type": contentType}
+	response, err := conn.DoRequest(body, http.MethodPost, "/artifacts/%s/add", params, header, name)
+	if err != nil {
+		return nil, err
+	}
+
+	report := new(entities.ArtifactAddReport)
+	return report, response.Process(report)
+}
+
+// List returns every artifact known to the service.
+func List(ctx context.Context) ([]*entities.ArtifactListReport, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(nil, http.MethodGet, "/artifacts/json", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []*entities.ArtifactListReport
+	return reports, response.Process(&reports)
+}
+
+// Inspect returns detailed information about a single artifact.
+func Inspect(ctx context.Context, name string, options *InspectOptions) (*entities.ArtifactInspectReport, error) {
+	if options == nil {
+		options = new(InspectOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := conn.DoRequest(nil, http.MethodGet, "/artifacts/%s/json", params, nil, name)
+	if err != nil {
+		return nil, err
+	}
+
+	report := new(entities.ArtifactInspectReport)
+	return report, response.Process(report)
+}
+
+// Remove deletes the named artifact from the local store.
+func Remove(ctx context.Context, name string) (*entities.ArtifactRemoveReport, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(nil, http.MethodDelete, "/artifacts/%s", nil, nil, name)
+	if err != nil {
+		return nil, err
+	}
+
+	report := new(entities.ArtifactRemoveReport)
+	return report, response.Process(report)
+}
+
+// pullRetryPolicy builds the client-side RetryPolicy for a single Pull call
+// out of conn's current policy (or bindings.DefaultRetryPolicy when conn has
+// none), with MaxRetries/RetryDelay overridden by whichever of the two the
+// caller set on options.
+func pullRetryPolicy(conn *bindings.Connection, options *PullOptions) (bindings.RetryPolicy, error) {
+	policy := bindings.DefaultRetryPolicy()
+	if conn.RetryPolicy != nil {
+		policy = *conn.RetryPolicy
+	}
+
+	if options.Changed("MaxRetries") {
+		policy.MaxRetries = int(options.GetMaxRetries())
+	}
+	if options.Changed("RetryDelay") {
+		delay, err := time.ParseDuration(options.GetRetryDelay())
+		if err != nil {
+			return policy, fmt.Errorf("parsing RetryDelay %q: %w", options.GetRetryDelay(), err)
+		}
+		policy.BaseDelay = delay
+	}
+	return policy, nil
+}
+
+// multipartFiles packages files as a multipart/form-data body, one part per
+// file, so Add can upload an arbitrary number of artifact blobs in a single
+// request.
+func multipartFiles(files []string) (*os.File, string, error) {
+	tmp, err := os.CreateTemp("", "podman-artifact-add-")
+	if err != nil {
+		return nil, "", err
+	}
+	writer := multipart.NewWriter(tmp)
+	for _, path := range files {
+		if err := addFilePart(writer, path); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	if _, err := tmp.Seek(0, 0); err != nil {
+		return nil, "", err
+	}
+	return tmp, writer.FormDataContentType(), nil
+}
+
+func addFilePart(writer *multipart.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	part, err := writer.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, f)
+	return err
+}
@@ -0,0 +1,62 @@
+// Package artifacts is the client side of the /libpod/artifacts/* REST
+// endpoints: it only builds requests and decodes responses. The local
+// storage and registry transfer logic those endpoints would dispatch to
+// lives in pkg/libartifact (ArtifactStore, RemoteOptions) and is not wired
+// to an API handler in this tree.
+package artifacts
+
+//go:generate go run ../generator/generator.go PullOptions
+//go:generate go run ../generator/generator.go PushOptions
+//go:generate go run ../generator/generator.go AddOptions
+//go:generate go run ../generator/generator.go InspectOptions
+
+// PullOptions are optional options for pulling an artifact from a registry.
+type PullOptions struct {
+	// AuthFilePath is the path to a containers-auth.json(5) file.
+	AuthFilePath *string
+	// CertDirPath is the path to certificates for the registry.
+	CertDirPath *string
+	// InsecureSkipTLSVerify allows an insecure (http, or unverified https)
+	// connection to the registry.
+	InsecureSkipTLSVerify *bool
+	// Username for authenticating with the registry.
+	Username *string
+	// Password for authenticating with the registry.
+	Password *string
+	// Quiet suppresses pull progress output.
+	Quiet *bool
+	// MaxRetries is the maximum number of attempts to pull the artifact.
+	MaxRetries *uint
+	// RetryDelay between attempts, as a time.ParseDuration string.
+	RetryDelay *string
+}
+
+// PushOptions are optional options for pushing an artifact to a registry.
+type PushOptions struct {
+	AuthFilePath               *string
+	CertDirPath                *string
+	InsecureSkipTLSVerify      *bool
+	Username                   *string
+	Password                   *string
+	DigestFile                 *string
+	EncryptionKeys             *[]string
+	EncryptLayers              *[]int
+	SignBySigstoreParamFileCLI *string
+	Quiet                      *bool
+}
+
+// AddOptions are optional options for adding a local artifact.
+type AddOptions struct {
+	// ArtifactType sets the manifest's artifactType field. Defaults to
+	// artifacttypes.DefaultArtifactType when empty.
+	ArtifactType *string
+	// FileType is the media type recorded for every blob being added.
+	FileType *string
+}
+
+// InspectOptions are optional options for inspecting an artifact.
+type InspectOptions struct {
+	// Remote, when true, inspects the named artifact directly on the
+	// registry instead of the local store.
+	Remote *bool
+}
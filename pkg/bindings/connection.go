@@ -2,14 +2,23 @@ package bindings
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // required by the known_hosts hashed-hostname format
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	stderrors "errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -18,6 +27,7 @@ import (
 
 	"github.com/blang/semver"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/kevinburke/ssh_config"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/ssh"
@@ -43,6 +53,51 @@ type APIResponse struct {
 type Connection struct {
 	Uri    *url.URL
 	Client *http.Client
+	// RetryPolicy controls how DoRequest retries failed requests made on
+	// this Connection. A nil RetryPolicy falls back to defaultRetryPolicy.
+	RetryPolicy *RetryPolicy
+}
+
+// RetryPolicy configures the retry behavior of Connection.DoRequest.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of attempts, including the first.
+	MaxRetries int
+	// BaseDelay is the delay before the first retry.
+	BaseDelay time.Duration
+	// CapDelay is the maximum delay between retries; BaseDelay doubles on
+	// every attempt up to this cap.
+	CapDelay time.Duration
+	// Jitter, when true, randomizes each computed delay uniformly in
+	// [0, delay) to avoid thundering-herd retries.
+	Jitter bool
+	// RetryableStatusCodes are the HTTP status codes that are retried.
+	// The zero value means none, use defaultRetryPolicy.RetryableStatusCodes
+	// for the usual 429/503 set.
+	RetryableStatusCodes map[int]bool
+}
+
+// defaultRetryPolicy is used whenever a Connection has no RetryPolicy of its
+// own, preserving today's "a few quick attempts" behavior while adding
+// Retry-After and idempotency awareness.
+var defaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  100 * time.Millisecond,
+	CapDelay:   3 * time.Second,
+	Jitter:     true,
+	RetryableStatusCodes: map[int]bool{
+		http.StatusTooManyRequests:    true,
+		http.StatusServiceUnavailable: true,
+	},
+}
+
+// idempotentMethods are the HTTP methods DoRequest treats as safe to resend.
+// A request body that cannot be rewound is still sent only once, whatever
+// the method: DoRequest stops once the first attempt has drained it.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodHead:   true,
+	http.MethodPut:    true,
+	http.MethodDelete: true,
 }
 
 type valueKey string
@@ -69,14 +124,51 @@ func NewConnection(ctx context.Context, uri string) (context.Context, error) {
 	return NewConnectionWithIdentity(ctx, uri, "")
 }
 
+// NewConnectionWithOptions is like NewConnectionWithIdentity but additionally
+// lets the caller install a RetryPolicy for the resulting Connection, e.g. to
+// plumb ArtifactPullOptions.MaxRetries/RetryDelay down from the caller.
+func NewConnectionWithOptions(ctx context.Context, uri string, passPhrase string, retryPolicy *RetryPolicy, identities ...string) (context.Context, error) {
+	ctx, err := NewConnectionWithIdentity(ctx, uri, passPhrase, identities...)
+	if err != nil {
+		return nil, err
+	}
+	if retryPolicy == nil {
+		return ctx, nil
+	}
+	return WithRetryPolicy(ctx, *retryPolicy)
+}
+
+// DefaultRetryPolicy returns the RetryPolicy a Connection falls back to when
+// none was set explicitly, so callers overriding only a subset of fields
+// (e.g. ArtifactPullOptions.MaxRetries/RetryDelay) have sane values to start
+// from for the rest. The returned RetryPolicy's RetryableStatusCodes map
+// must be treated as read-only.
+func DefaultRetryPolicy() RetryPolicy {
+	return defaultRetryPolicy
+}
+
+// WithRetryPolicy returns a copy of ctx whose Connection uses the given
+// RetryPolicy for subsequent DoRequest calls.
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) (context.Context, error) {
+	c, err := GetClient(ctx)
+	if err != nil {
+		return ctx, err
+	}
+	updated := *c
+	updated.RetryPolicy = &policy
+	return context.WithValue(ctx, clientKey, &updated), nil
+}
+
 // NewConnection takes a URI as a string and returns a context with the
 // Connection embedded as a value.  This context needs to be passed to each
 // endpoint to work correctly.
 //
 // A valid URI connection should be scheme://
 // For example tcp://localhost:<port>
+// or tcps://localhost:<port> (or tcp://…?tls=1) for TLS/mTLS
 // or unix:///run/podman/podman.sock
 // or ssh://<user>@<host>[:port]/run/podman/podman.sock?secure=True
+// or npipe:////./pipe/podman-machine-default (Windows only)
 func NewConnectionWithIdentity(ctx context.Context, uri string, passPhrase string, identities ...string) (context.Context, error) {
 	var (
 		err    error
@@ -121,6 +213,18 @@ func NewConnectionWithIdentity(ctx context.Context, uri string, passPhrase strin
 			return nil, errors.New("tcp URIs should begin with tcp://")
 		}
 		connection, err = tcpClient(_url)
+	case "tcps":
+		if !strings.HasPrefix(uri, "tcps://") {
+			return nil, errors.New("tcps URIs should begin with tcps://")
+		}
+		connection, err = tcpClient(_url)
+	case "npipe":
+		if !strings.HasPrefix(uri, "npipe:///") {
+			// autofix npipe://pipe_name vs npipe:///pipe_name
+			_url.Path = JoinURL(_url.Host, _url.Path)
+			_url.Host = ""
+		}
+		connection, err = npipeClient(_url)
 	default:
 		return nil, errors.Errorf("'%s' is not a supported schema", _url.Scheme)
 	}
@@ -139,17 +243,84 @@ func tcpClient(_url *url.URL) (Connection, error) {
 	connection := Connection{
 		Uri: _url,
 	}
-	connection.Client = &http.Client{
-		Transport: &http.Transport{
-			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
-				return net.Dial("tcp", _url.Host)
-			},
-			DisableCompression: true,
-		},
+	transport := &http.Transport{
+		DisableCompression: true,
+	}
+
+	tlsConfig, err := tcpTLSConfig(_url)
+	if err != nil {
+		return Connection{}, err
 	}
+	switch {
+	case tlsConfig != nil:
+		transport.TLSClientConfig = tlsConfig
+		transport.DialTLSContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return tls.Dial("tcp", _url.Host, tlsConfig)
+		}
+	default:
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return net.Dial("tcp", _url.Host)
+		}
+	}
+
+	connection.Client = &http.Client{Transport: transport}
 	return connection, nil
 }
 
+// tcpTLSConfig builds a *tls.Config for tcpClient out of the tcps:// scheme
+// or tcp://…?tls=1&cacert=…&cert=…&key=…&servername=… query parameters,
+// falling back to the CONTAINER_TLS_CA/CONTAINER_TLS_CERT/CONTAINER_TLS_KEY
+// env vars when the corresponding query parameter is absent. It returns a
+// nil config (and no error) for plain, non-TLS tcp:// connections.
+func tcpTLSConfig(_url *url.URL) (*tls.Config, error) {
+	query := _url.Query()
+	secure, _ := strconv.ParseBool(query.Get("tls"))
+	if _url.Scheme != "tcps" && !secure {
+		return nil, nil
+	}
+
+	caCert := firstNonEmpty(query.Get("cacert"), os.Getenv("CONTAINER_TLS_CA"))
+	clientCert := firstNonEmpty(query.Get("cert"), os.Getenv("CONTAINER_TLS_CERT"))
+	clientKey := firstNonEmpty(query.Get("key"), os.Getenv("CONTAINER_TLS_KEY"))
+	serverName := query.Get("servername")
+	if serverName == "" {
+		serverName = _url.Hostname()
+	}
+
+	tlsConfig := &tls.Config{ServerName: serverName} //nolint:gosec // InsecureSkipVerify defaults to false
+
+	if caCert != "" {
+		pem, err := ioutil.ReadFile(caCert)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading CA certificate %q", caCert)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no certificates found in %q", caCert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if clientCert != "" && clientKey != "" {
+		cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "loading client certificate for mTLS")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 // pingNewConnection pings to make sure the RESTFUL service is up
 // and running. it should only be used when initializing a connection
 func pingNewConnection(ctx context.Context) error {
@@ -160,7 +331,16 @@ func pingNewConnection(ctx context.Context) error {
 	// the ping endpoint sits at / in this case
 	response, err := client.DoRequest(nil, http.MethodGet, "../../../_ping", nil, nil)
 	if err != nil {
-		return err
+		var certErr x509.UnknownAuthorityError
+		var hostnameErr x509.HostnameError
+		switch {
+		case stderrors.As(err, &certErr):
+			return errors.Wrapf(err, "TLS handshake with %s failed: server certificate is not signed by the configured CA", client.Uri.Host)
+		case stderrors.As(err, &hostnameErr):
+			return errors.Wrapf(err, "TLS handshake with %s failed: certificate does not match hostname, check servername=", client.Uri.Host)
+		default:
+			return err
+		}
 	}
 
 	if response.StatusCode == http.StatusOK {
@@ -185,7 +365,48 @@ func pingNewConnection(ctx context.Context) error {
 	return errors.Errorf("ping response was %q", response.StatusCode)
 }
 
+// sshUserConfig is the subset of ~/.ssh/config that we resolve on behalf of
+// the caller when a value was not supplied in the URI or identities.
+func sshUserConfig() *ssh_config.Config {
+	path := filepath.Join(homedir.HomeDir(), ".ssh", "config")
+	f, err := os.Open(path)
+	if err != nil {
+		logrus.Debugf("Unable to open %s: %v", path, err)
+		return nil
+	}
+	defer f.Close()
+
+	cfg, err := ssh_config.Decode(f)
+	if err != nil {
+		logrus.Debugf("Unable to parse %s: %v", path, err)
+		return nil
+	}
+	return cfg
+}
+
+// sshConfigGet returns ssh_config.Get(host, key), tolerating a nil config
+// (e.g. no ~/.ssh/config present).
+func sshConfigGet(cfg *ssh_config.Config, host, key string) string {
+	if cfg == nil {
+		return ""
+	}
+	val, err := cfg.Get(host, key)
+	if err != nil {
+		return ""
+	}
+	return val
+}
+
 func sshClient(_url *url.URL, secure bool, passPhrase string, identities ...string) (Connection, error) {
+	cfg := sshUserConfig()
+	host := _url.Hostname()
+
+	if len(identities) == 0 {
+		if identityFile := sshConfigGet(cfg, host, "IdentityFile"); identityFile != "" {
+			identities = append(identities, identityFile)
+		}
+	}
+
 	var authMethods []ssh.AuthMethod
 
 	for _, i := range identities {
@@ -197,7 +418,8 @@ func sshClient(_url *url.URL, secure bool, passPhrase string, identities ...stri
 		authMethods = append(authMethods, auth)
 	}
 
-	if sock, found := os.LookupEnv("SSH_AUTH_SOCK"); found {
+	identitiesOnly := strings.EqualFold(sshConfigGet(cfg, host, "IdentitiesOnly"), "yes")
+	if sock, found := os.LookupEnv("SSH_AUTH_SOCK"); found && !identitiesOnly {
 		logrus.Debugf("Found SSH_AUTH_SOCK %q, ssh-agent signer enabled", sock)
 
 		c, err := net.Dial("unix", sock)
@@ -212,36 +434,52 @@ func sshClient(_url *url.URL, secure bool, passPhrase string, identities ...stri
 		authMethods = append(authMethods, ssh.Password(pw))
 	}
 
-	callback := ssh.InsecureIgnoreHostKey()
-	if secure {
-		key := hostKey(_url.Hostname())
-		if key != nil {
-			callback = ssh.FixedHostKey(key)
-		}
+	callback := hostKeyCallback(secure, host)
+
+	user := _url.User.Username()
+	if user == "" {
+		user = sshConfigGet(cfg, host, "User")
 	}
 
 	port := _url.Port()
+	if port == "" {
+		port = sshConfigGet(cfg, host, "Port")
+	}
 	if port == "" {
 		port = "22"
 	}
 
-	bastion, err := ssh.Dial("tcp",
-		net.JoinHostPort(_url.Hostname(), port),
-		&ssh.ClientConfig{
-			User:            _url.User.Username(),
-			Auth:            authMethods,
-			HostKeyCallback: callback,
-			HostKeyAlgorithms: []string{
-				ssh.KeyAlgoRSA,
-				ssh.KeyAlgoDSA,
-				ssh.KeyAlgoECDSA256,
-				ssh.KeyAlgoECDSA384,
-				ssh.KeyAlgoECDSA521,
-				ssh.KeyAlgoED25519,
-			},
-			Timeout: 5 * time.Second,
+	hostname := host
+	if configHostname := sshConfigGet(cfg, host, "HostName"); configHostname != "" {
+		hostname = configHostname
+	}
+
+	clientConfig := &ssh.ClientConfig{
+		User:            user,
+		Auth:            authMethods,
+		HostKeyCallback: callback,
+		HostKeyAlgorithms: []string{
+			ssh.KeyAlgoRSA,
+			ssh.KeyAlgoDSA,
+			ssh.KeyAlgoECDSA256,
+			ssh.KeyAlgoECDSA384,
+			ssh.KeyAlgoECDSA521,
+			ssh.KeyAlgoED25519,
 		},
-	)
+		Timeout: 5 * time.Second,
+	}
+
+	proxyJump := _url.Query().Get("proxyjump")
+	if proxyJump == "" {
+		proxyJump = sshConfigGet(cfg, host, "ProxyJump")
+	}
+
+	proxyCommand := _url.Query().Get("proxycommand")
+	if proxyCommand == "" {
+		proxyCommand = sshConfigGet(cfg, host, "ProxyCommand")
+	}
+
+	bastion, err := dialViaJumpHosts(proxyCommand, proxyJump, hostname, port, user, clientConfig, secure)
 	if err != nil {
 		return Connection{}, errors.Wrapf(err, "Connection to bastion host (%s) failed.", _url.String())
 	}
@@ -256,6 +494,156 @@ func sshClient(_url *url.URL, secure bool, passPhrase string, identities ...stri
 	return connection, nil
 }
 
+// dialViaJumpHosts dials the final host:port, optionally hopping through a
+// comma-separated ProxyJump chain (each hop specified as [user@]host[:port])
+// first, or through an arbitrary ProxyCommand. Every hop in a ProxyJump
+// chain is dialed with its own copy of clientConfig, with HostKeyCallback
+// replaced by one resolved against that hop's own hostname (secure controls
+// whether that lookup is enforced or skipped, exactly as it did for
+// clientConfig's own callback); only the final host uses clientConfig's
+// HostKeyCallback as-is. Intermediate hops are only used to tunnel the TCP
+// connection for the next hop in the chain. proxyCommand takes precedence
+// over proxyJump when both are set, the same way OpenSSH treats them as
+// mutually exclusive directives.
+func dialViaJumpHosts(proxyCommand, proxyJump, host, port, user string, clientConfig *ssh.ClientConfig, secure bool) (*ssh.Client, error) {
+	if proxyCommand != "" {
+		conn, err := dialProxyCommand(proxyCommand, host, port, user)
+		if err != nil {
+			return nil, errors.Wrapf(err, "ProxyCommand %q failed", proxyCommand)
+		}
+		addr := net.JoinHostPort(host, port)
+		ncc, chans, reqs, err := ssh.NewClientConn(conn, addr, clientConfig)
+		if err != nil {
+			return nil, errors.Wrapf(err, "handshake with %s via ProxyCommand failed", addr)
+		}
+		return ssh.NewClient(ncc, chans, reqs), nil
+	}
+
+	if proxyJump == "" {
+		return ssh.Dial("tcp", net.JoinHostPort(host, port), clientConfig)
+	}
+
+	var (
+		client *ssh.Client
+		err    error
+	)
+	hops := strings.Split(proxyJump, ",")
+	for _, hop := range hops {
+		hopConfig := *clientConfig
+		hopHost := hop
+		if u, h, found := strings.Cut(hop, "@"); found {
+			hopConfig.User = u
+			hopHost = h
+		}
+		hopHostname, hopPort, err := net.SplitHostPort(hopHost)
+		if err != nil {
+			hopHostname = hopHost
+			hopPort = "22"
+		}
+		addr := net.JoinHostPort(hopHostname, hopPort)
+		hopConfig.HostKeyCallback = hostKeyCallback(secure, hopHostname)
+
+		if client == nil {
+			client, err = ssh.Dial("tcp", addr, &hopConfig)
+		} else {
+			var conn net.Conn
+			conn, err = client.Dial("tcp", addr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "jump host %s unreachable", addr)
+			}
+			ncc, chans, reqs, dialErr := ssh.NewClientConn(conn, addr, &hopConfig)
+			if dialErr != nil {
+				return nil, errors.Wrapf(dialErr, "handshake with jump host %s failed", addr)
+			}
+			client = ssh.NewClient(ncc, chans, reqs)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "jump host %s unreachable", addr)
+		}
+	}
+
+	addr := net.JoinHostPort(host, port)
+	conn, err := client.Dial("tcp", addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "final host %s unreachable via jump hosts", addr)
+	}
+	ncc, chans, reqs, err := ssh.NewClientConn(conn, addr, clientConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "handshake with %s failed", addr)
+	}
+	return ssh.NewClient(ncc, chans, reqs), nil
+}
+
+// expandProxyCommandTokens expands the %h, %p and %r tokens ssh_config
+// allows in a ProxyCommand directive into host, port and user respectively.
+func expandProxyCommandTokens(command, host, port, user string) string {
+	replacer := strings.NewReplacer("%h", host, "%p", port, "%r", user)
+	return replacer.Replace(command)
+}
+
+// dialProxyCommand runs command (after %h/%p/%r expansion) through the
+// user's shell, the way OpenSSH's ProxyCommand does, and returns a net.Conn
+// backed by its stdin/stdout.
+func dialProxyCommand(command, host, port, user string) (net.Conn, error) {
+	expanded := expandProxyCommandTokens(command, host, port, user)
+
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+	cmd := exec.Command(shell, "-c", expanded)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &proxyCommandConn{stdout: stdout, stdin: stdin, cmd: cmd}, nil
+}
+
+// proxyCommandConn adapts a ProxyCommand subprocess's stdin/stdout pipes to
+// the net.Conn interface ssh.NewClientConn expects to do its handshake over.
+type proxyCommandConn struct {
+	stdout io.ReadCloser
+	stdin  io.WriteCloser
+	cmd    *exec.Cmd
+}
+
+func (p *proxyCommandConn) Read(b []byte) (int, error)  { return p.stdout.Read(b) }
+func (p *proxyCommandConn) Write(b []byte) (int, error) { return p.stdin.Write(b) }
+
+func (p *proxyCommandConn) Close() error {
+	stdinErr := p.stdin.Close()
+	stdoutErr := p.stdout.Close()
+	_ = p.cmd.Wait()
+	if stdinErr != nil {
+		return stdinErr
+	}
+	return stdoutErr
+}
+
+func (p *proxyCommandConn) LocalAddr() net.Addr  { return proxyCommandAddr{} }
+func (p *proxyCommandConn) RemoteAddr() net.Addr { return proxyCommandAddr{} }
+
+func (p *proxyCommandConn) SetDeadline(t time.Time) error      { return nil }
+func (p *proxyCommandConn) SetReadDeadline(t time.Time) error  { return nil }
+func (p *proxyCommandConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// proxyCommandAddr is a no-op net.Addr for proxyCommandConn, which has no
+// real local/remote socket address since it is backed by pipes to a
+// subprocess.
+type proxyCommandAddr struct{}
+
+func (proxyCommandAddr) Network() string { return "proxycommand" }
+func (proxyCommandAddr) String() string  { return "proxycommand" }
+
 func unixClient(_url *url.URL) (Connection, error) {
 	connection := Connection{Uri: _url}
 	connection.Client = &http.Client{
@@ -273,6 +661,7 @@ func unixClient(_url *url.URL) (Connection, error) {
 func (c *Connection) DoRequest(httpBody io.Reader, httpMethod, endpoint string, queryParams url.Values, header map[string]string, pathValues ...string) (*APIResponse, error) {
 	var (
 		err      error
+		req      *http.Request
 		response *http.Response
 	)
 	safePathValues := make([]interface{}, len(pathValues))
@@ -284,28 +673,139 @@ func (c *Connection) DoRequest(httpBody io.Reader, httpMethod, endpoint string,
 	// usage
 	safeEndpoint := fmt.Sprintf(endpoint, safePathValues...)
 	e := BasePath.String() + safeEndpoint
-	req, err := http.NewRequest(httpMethod, e, httpBody)
-	if err != nil {
-		return nil, err
-	}
-	if len(queryParams) > 0 {
-		req.URL.RawQuery = queryParams.Encode()
+
+	policy := c.RetryPolicy
+	if policy == nil {
+		policy = &defaultRetryPolicy
 	}
-	for key, val := range header {
-		req.Header.Set(key, val)
+	bodyBytes, rewindable := rewindableBodyBytes(httpBody)
+	canRetryBody := rewindable || idempotentMethods[httpMethod]
+
+	maxRetries := policy.MaxRetries
+	if maxRetries < 1 {
+		maxRetries = 1
 	}
-	req = req.WithContext(context.WithValue(context.Background(), clientKey, c))
-	// Give the Do three chances in the case of a comm/service hiccup
-	for i := 0; i < 3; i++ {
+
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		body := httpBody
+		if rewindable {
+			body = bytes.NewReader(bodyBytes)
+		} else if attempt > 0 {
+			// The original reader was already drained by a previous
+			// attempt and we have no way to rewind it.
+			break
+		}
+
+		req, err = http.NewRequest(httpMethod, e, body)
+		if err != nil {
+			return nil, err
+		}
+		if len(queryParams) > 0 {
+			req.URL.RawQuery = queryParams.Encode()
+		}
+		for key, val := range header {
+			req.Header.Set(key, val)
+		}
+		req = req.WithContext(context.WithValue(context.Background(), clientKey, c))
+
 		response, err = c.Client.Do(req) // nolint
-		if err == nil {
+
+		retry := false
+		switch {
+		case err != nil:
+			retry = isRetryableError(err)
+		case policy.RetryableStatusCodes[response.StatusCode]:
+			retry = true
+		}
+
+		if !retry || attempt == maxRetries-1 || !canRetryBody {
 			break
 		}
-		time.Sleep(time.Duration(i*100) * time.Millisecond)
+
+		delay := retryDelay(policy, attempt)
+		if response != nil {
+			if ra, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+				delay = ra
+			}
+		}
+		time.Sleep(delay)
 	}
 	return &APIResponse{response, req}, err
 }
 
+// rewindableBodyBytes returns the full contents of body and true when body is
+// a type DoRequest knows how to safely re-read on retry (bytes.Buffer,
+// bytes.Reader, or strings.Reader); otherwise it returns false and DoRequest
+// must not reuse body across attempts.
+func rewindableBodyBytes(body io.Reader) ([]byte, bool) {
+	switch b := body.(type) {
+	case nil:
+		return nil, true
+	case *bytes.Buffer:
+		return b.Bytes(), true
+	case *bytes.Reader:
+		data, err := io.ReadAll(b)
+		if err != nil {
+			return nil, false
+		}
+		b.Seek(0, io.SeekStart) // nolint:errcheck
+		return data, true
+	case *strings.Reader:
+		data, err := io.ReadAll(b)
+		if err != nil {
+			return nil, false
+		}
+		b.Seek(0, io.SeekStart) // nolint:errcheck
+		return data, true
+	default:
+		return nil, false
+	}
+}
+
+// isRetryableError reports whether err is a transient networking error worth
+// retrying, such as a connection reset or an EOF from a dropped keep-alive.
+func isRetryableError(err error) bool {
+	if stderrors.Is(err, io.EOF) {
+		return true
+	}
+	var opErr *net.OpError
+	return stderrors.As(err, &opErr)
+}
+
+// retryDelay computes the exponential backoff delay for the given zero-based
+// attempt number, applying policy.CapDelay and optional jitter.
+func retryDelay(policy *RetryPolicy, attempt int) time.Duration {
+	delay := policy.BaseDelay << attempt
+	if policy.CapDelay > 0 && delay > policy.CapDelay {
+		delay = policy.CapDelay
+	}
+	if policy.Jitter && delay > 0 {
+		delay = time.Duration(rand.Int63n(int64(delay)))
+	}
+	return delay
+}
+
+// parseRetryAfter parses a Retry-After header value, which per RFC 9110 is
+// either a number of delta-seconds or an HTTP-date.
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
 // FiltersToString converts our typical filter format of a
 // map[string][]string to a query/html safe string.
 func FiltersToString(filters map[string][]string) (string, error) {
@@ -373,6 +873,21 @@ func promptPassphrase() {
 	passPhrase = phrase
 }
 
+// hostKeyCallback resolves the ssh.HostKeyCallback to use for a single host:
+// ssh.InsecureIgnoreHostKey when secure is false or no known_hosts entry is
+// found for host, ssh.FixedHostKey against that entry otherwise. Each hop of
+// a ProxyJump chain must resolve its own callback this way against its own
+// hostname rather than sharing one built for a different host.
+func hostKeyCallback(secure bool, host string) ssh.HostKeyCallback {
+	if !secure {
+		return ssh.InsecureIgnoreHostKey()
+	}
+	if key := hostKey(host); key != nil {
+		return ssh.FixedHostKey(key)
+	}
+	return ssh.InsecureIgnoreHostKey()
+}
+
 func hostKey(host string) ssh.PublicKey {
 	// parse OpenSSH known_hosts file
 	// ssh or use ssh-keyscan to get initial key
@@ -392,7 +907,7 @@ func hostKey(host string) ssh.PublicKey {
 		}
 
 		for _, h := range hosts {
-			if h == host {
+			if h == host || hashedHostMatches(h, host) {
 				return key
 			}
 		}
@@ -400,3 +915,28 @@ func hostKey(host string) ssh.PublicKey {
 
 	return nil
 }
+
+// hashedHostMatches reports whether a known_hosts host entry in the hashed
+// "|1|salt|hash" form (see ssh-keygen -H) matches host. Entries that are not
+// hashed are ignored here since those are already compared by literal
+// equality in hostKey.
+func hashedHostMatches(entry, host string) bool {
+	if !strings.HasPrefix(entry, "|1|") {
+		return false
+	}
+	parts := strings.Split(entry, "|")
+	if len(parts) != 4 {
+		return false
+	}
+	salt, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	want, err := base64.StdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha1.New, salt)
+	mac.Write([]byte(host))
+	return hmac.Equal(mac.Sum(nil), want)
+}
@@ -0,0 +1,110 @@
+package bindings
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: 100 * time.Millisecond, CapDelay: 300 * time.Millisecond}
+
+	tests := []struct {
+		name    string
+		attempt int
+		want    time.Duration
+	}{
+		{"first retry", 0, 100 * time.Millisecond},
+		{"doubles", 1, 200 * time.Millisecond},
+		{"capped", 2, 300 * time.Millisecond},
+		{"stays capped", 5, 300 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryDelay(policy, tt.attempt); got != tt.want {
+				t.Errorf("retryDelay(attempt=%d) = %v, want %v", tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelayJitter(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: 100 * time.Millisecond, Jitter: true}
+	for i := 0; i < 20; i++ {
+		if got := retryDelay(policy, 0); got < 0 || got >= 100*time.Millisecond {
+			t.Fatalf("retryDelay with jitter = %v, want in [0, 100ms)", got)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"empty", "", 0, false},
+		{"seconds", "5", 5 * time.Second, true},
+		{"negative seconds", "-1", 0, false},
+		{"garbage", "not-a-delay-or-date", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.header)
+			if ok != tt.wantOK {
+				t.Fatalf("parseRetryAfter(%q) ok = %v, want %v", tt.header, ok, tt.wantOK)
+			}
+			if tt.name == "seconds" && got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfterFutureDate(t *testing.T) {
+	future := time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)
+	got, ok := parseRetryAfter(future)
+	if !ok {
+		t.Fatalf("parseRetryAfter(%q) ok = false, want true", future)
+	}
+	if got <= 0 || got > 30*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, want in (0, 30s]", future, got)
+	}
+}
+
+func TestHashedHostMatches(t *testing.T) {
+	// Generated with: ssh-keygen -H -f known_hosts, entry for "example.com".
+	const entry = "|1|YHuvloxyUQ9o8aNhkKyDP3jKqow=|CJtg3W39hum6w/uIAA+4DnQ5f9M="
+
+	tests := []struct {
+		name  string
+		entry string
+		host  string
+		want  bool
+	}{
+		{"matching host", entry, "example.com", true},
+		{"different host", entry, "example.org", false},
+		{"unhashed entry", "example.com", "example.com", false},
+		{"malformed entry", "|1|onlyonefield", "example.com", false},
+		{"bad base64 salt", "|1|not-base64!!|3VKEhrORWlDvaG2BFHrVGtK84ZM=", "example.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hashedHostMatches(tt.entry, tt.host); got != tt.want {
+				t.Errorf("hashedHostMatches(%q, %q) = %v, want %v", tt.entry, tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandProxyCommandTokens(t *testing.T) {
+	got := expandProxyCommandTokens("nc -x bastion:1080 %h %p -l %r", "example.com", "22", "root")
+	want := "nc -x bastion:1080 example.com 22 -l root"
+	if got != want {
+		t.Errorf("expandProxyCommandTokens() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+// +build !windows
+
+package bindings
+
+import (
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// npipeClient is only supported on Windows.
+func npipeClient(_url *url.URL) (Connection, error) {
+	return Connection{}, errors.New("npipe:// connections are only supported on Windows")
+}
@@ -0,0 +1,35 @@
+//go:build windows
+// +build windows
+
+package bindings
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// npipeClient builds a Connection backed by a Windows named pipe, e.g. the
+// one exposed by `podman machine` on WSL at
+// \\.\pipe\podman-machine-default.
+func npipeClient(_url *url.URL) (Connection, error) {
+	// url.Parse leaves _url.Host empty for the canonical npipe:////./pipe/<name>
+	// URI and puts the rest, leading "//" included, in _url.Path (so Path is
+	// "//./pipe/<name>"); replacing "/" with "\" there alone already produces
+	// the native \\.\pipe\<name> form, with no separate \\ or Host to add.
+	pipe := strings.ReplaceAll(_url.Path, "/", `\`)
+	connection := Connection{Uri: _url}
+	connection.Client = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return winio.DialPipeContext(ctx, pipe)
+			},
+			DisableCompression: true,
+		},
+	}
+	return connection, nil
+}
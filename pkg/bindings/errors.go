@@ -0,0 +1,56 @@
+package bindings
+
+import (
+	"io"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// ErrorModel is returned in the body of a non-2xx/3xx API response.
+type ErrorModel struct {
+	// Because is the underlying cause of the error, if any.
+	Because string `json:"cause,omitempty"`
+	// Message describes the error.
+	Message string `json:"message,omitempty"`
+	// ResponseCode is the HTTP status code of the response this error came
+	// from; it is filled in by Process, not by the server.
+	ResponseCode int `json:"response"`
+}
+
+func (e *ErrorModel) Error() string {
+	return e.Message
+}
+
+func (e *ErrorModel) Cause() error {
+	if e.Because == "" {
+		return nil
+	}
+	return errors.New(e.Because)
+}
+
+// Process reads the response body, decoding it into unmarshalInto on a
+// successful (2xx/3xx) response, or into an *ErrorModel otherwise.
+// unmarshalInto may be nil if the caller does not care about the body of a
+// successful response.
+func (h *APIResponse) Process(unmarshalInto interface{}) error {
+	defer h.Body.Close()
+	data, err := io.ReadAll(h.Body)
+	if err != nil {
+		return errors.Wrap(err, "unable to process API response")
+	}
+
+	if h.IsSuccess() || h.IsRedirection() {
+		if unmarshalInto == nil {
+			return nil
+		}
+		return jsoniter.Unmarshal(data, unmarshalInto)
+	}
+
+	errModel := new(ErrorModel)
+	if err := jsoniter.Unmarshal(data, errModel); err != nil {
+		return errors.Wrap(err, "unable to decode API error response")
+	}
+	errModel.ResponseCode = h.StatusCode
+	return errModel
+}
@@ -0,0 +1,119 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/containers/podman/v4/pkg/bindings"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+)
+
+// Create makes a new secret. Payload is the new secret's data; it is ignored,
+// and no request body is sent, when options.External is set, since the
+// payload for an external secret lives in the driver, not in Podman.
+func Create(ctx context.Context, payload []byte, options *CreateOptions) (*entities.SecretCreateReport, error) {
+	if options == nil {
+		options = new(CreateOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.Reader
+	if !options.GetExternal() {
+		body = bytes.NewReader(payload)
+	}
+
+	response, err := conn.DoRequest(body, http.MethodPost, "/secrets/create", params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := new(entities.SecretCreateReport)
+	return report, response.Process(report)
+}
+
+// Rotate replaces the payload of the named secret, bumping its Version, while
+// preserving the secret's ID. Unlike Create with WithReplace, Rotate always
+// increments Version and never reuses it.
+//
+// The actual versioning logic (new rotations bump Version, and an old
+// payload stays addressable by "name@v<N>" for rollback) lives server-side
+// in pkg/secrets.Manager, which layers version tracking on top of a plain
+// secrets Driver. This client call only builds the request; this tree has
+// no pkg/api, so there is no /secrets/{id}/rotate handler yet dispatching to
+// a Manager for it.
+func Rotate(ctx context.Context, nameOrID string, payload []byte, options *CreateOptions) (*entities.SecretRotateReport, error) {
+	if options == nil {
+		options = new(CreateOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := conn.DoRequest(bytes.NewReader(payload), http.MethodPost, "/secrets/%s/rotate", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := new(entities.SecretRotateReport)
+	return report, response.Process(report)
+}
+
+// Inspect returns low-level information about a single secret.
+func Inspect(ctx context.Context, nameOrID string) (*entities.SecretInfoReport, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(nil, http.MethodGet, "/secrets/%s/json", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := new(entities.SecretInfoReport)
+	return report, response.Process(report)
+}
+
+// List returns all secrets known to the service.
+func List(ctx context.Context) ([]*entities.SecretInfoReport, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(nil, http.MethodGet, "/secrets/json", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []*entities.SecretInfoReport
+	return reports, response.Process(&reports)
+}
+
+// Remove deletes the named secret.
+func Remove(ctx context.Context, nameOrID string) (*entities.SecretRemoveReport, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(nil, http.MethodDelete, "/secrets/%s", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := new(entities.SecretRemoveReport)
+	report.ID = nameOrID
+	return report, response.Process(report)
+}
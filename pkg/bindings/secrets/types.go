@@ -0,0 +1,52 @@
+package secrets
+
+//go:generate go run ../generator/generator.go CreateOptions
+
+// CreateOptions are optional options for creating secrets
+type CreateOptions struct {
+	// Name of the secret
+	Name *string
+	// Driver is the secret driver
+	Driver *string
+	// DriverOpts are driver specific options. A handful of keys are
+	// reserved for out-of-process drivers such as pkg/secrets/socket:
+	//
+	//	socket=  - path to the driver plugin's listening Unix socket
+	//	timeout= - a time.ParseDuration string for the driver's per-call
+	//	           deadline
+	DriverOpts map[string]string
+	// Labels are the labels of the secret
+	Labels map[string]string
+	// Replace, if true, lets Create atomically replace the payload of an
+	// existing secret with the same Name instead of erroring out, while
+	// preserving its ID. See also Rotate, which is the dedicated call for
+	// this and additionally bumps Version.
+	Replace *bool
+	// Version is the caller-supplied version to record for this secret
+	// payload; when empty, the server assigns the next monotonically
+	// increasing version.
+	Version *string
+	// ExpiresAt is the RFC 3339 timestamp at which the secret expires.
+	// Once this has passed, pkg/secrets.Manager.Lookup refuses the secret
+	// and Manager.Prune removes it (old versions stay reachable by
+	// "name@v<N>" for rollback regardless). Set via WithExpiresAt or
+	// WithTTL; the two are equivalent, WithTTL just resolves to a
+	// timestamp at call time. This client call only builds the request;
+	// this tree has no pkg/api, so there is no handler yet forwarding it
+	// to a Manager.
+	ExpiresAt *string
+	// Immutable, if true, causes pkg/secrets.Manager.Rotate to refuse any
+	// later rotation of this secret's name. See ExpiresAt for the same
+	// caveat about this client call not reaching a server in this tree.
+	Immutable *bool
+	// External, if true, registers only a reference to a payload that
+	// lives in the out-of-process driver named by Driver/DriverOpts,
+	// instead of storing bytes in Podman's own database. Create sends no
+	// request body when External is set; the server resolves the value
+	// fresh from the driver on every container start.
+	External *bool
+	// ExternalRef is the driver-specific reference (e.g. a Vault path or
+	// a sops file URI) the driver resolves on lookup. Only meaningful
+	// when External is true.
+	ExternalRef *string
+}
@@ -3,6 +3,7 @@ package secrets
 
 import (
 	"net/url"
+	"time"
 
 	"github.com/containers/podman/v4/pkg/bindings/internal/util"
 )
@@ -76,3 +77,99 @@ func (o *CreateOptions) GetLabels() map[string]string {
 	}
 	return o.Labels
 }
+
+// WithReplace set field Replace to given value
+func (o *CreateOptions) WithReplace(value bool) *CreateOptions {
+	o.Replace = &value
+	return o
+}
+
+// GetReplace returns value of field Replace
+func (o *CreateOptions) GetReplace() bool {
+	if o.Replace == nil {
+		var z bool
+		return z
+	}
+	return *o.Replace
+}
+
+// WithVersion set field Version to given value
+func (o *CreateOptions) WithVersion(value string) *CreateOptions {
+	o.Version = &value
+	return o
+}
+
+// GetVersion returns value of field Version
+func (o *CreateOptions) GetVersion() string {
+	if o.Version == nil {
+		var z string
+		return z
+	}
+	return *o.Version
+}
+
+// WithExpiresAt set field ExpiresAt to given value
+func (o *CreateOptions) WithExpiresAt(value time.Time) *CreateOptions {
+	v := value.UTC().Format(time.RFC3339)
+	o.ExpiresAt = &v
+	return o
+}
+
+// WithTTL sets ExpiresAt to the current time plus value.
+func (o *CreateOptions) WithTTL(value time.Duration) *CreateOptions {
+	return o.WithExpiresAt(time.Now().Add(value))
+}
+
+// GetExpiresAt returns value of field ExpiresAt
+func (o *CreateOptions) GetExpiresAt() string {
+	if o.ExpiresAt == nil {
+		var z string
+		return z
+	}
+	return *o.ExpiresAt
+}
+
+// WithImmutable set field Immutable to given value
+func (o *CreateOptions) WithImmutable(value bool) *CreateOptions {
+	o.Immutable = &value
+	return o
+}
+
+// GetImmutable returns value of field Immutable
+func (o *CreateOptions) GetImmutable() bool {
+	if o.Immutable == nil {
+		var z bool
+		return z
+	}
+	return *o.Immutable
+}
+
+// WithExternal set field External to given value
+func (o *CreateOptions) WithExternal(value bool) *CreateOptions {
+	o.External = &value
+	return o
+}
+
+// GetExternal returns value of field External
+func (o *CreateOptions) GetExternal() bool {
+	if o.External == nil {
+		var z bool
+		return z
+	}
+	return *o.External
+}
+
+// WithExternalRef set field ExternalRef to given value
+func (o *CreateOptions) WithExternalRef(value string) *CreateOptions {
+	o.ExternalRef = &value
+	return o
+}
+
+// GetExternalRef returns value of field ExternalRef
+func (o *CreateOptions) GetExternalRef() string {
+	if o.ExternalRef == nil {
+		var z string
+		return z
+	}
+	return *o.ExternalRef
+}
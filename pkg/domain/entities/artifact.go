@@ -6,7 +6,7 @@ import (
 
 	"github.com/containers/image/v5/types"
 	encconfig "github.com/containers/ocicrypt/config"
-	"github.com/containers/podman/v5/pkg/libartifact"
+	"github.com/containers/podman/v4/pkg/libartifact"
 )
 
 type ArtifactAddoptions struct {
@@ -0,0 +1,44 @@
+package entities
+
+// SecretCreateReport is the response from creating a secret.
+type SecretCreateReport struct {
+	ID string
+}
+
+// SecretRotateReport is the response from rotating a secret's payload.
+type SecretRotateReport struct {
+	ID string
+	// Version is the new, incremented version of the secret's payload.
+	Version int
+}
+
+// SecretRemoveReport is the response from removing a secret.
+type SecretRemoveReport struct {
+	ID  string
+	Err error
+}
+
+// SecretInfoReport is the output of inspecting or listing a secret.
+type SecretInfoReport struct {
+	ID        string
+	CreatedAt string
+	UpdatedAt string
+	// Version is the version Lookup currently resolves the secret's
+	// payload to; it starts at 1 and is incremented by Rotate.
+	Version int
+	Spec    SecretSpec
+}
+
+// SecretSpec is the secret's metadata as supplied (or defaulted) at creation.
+type SecretSpec struct {
+	Name   string
+	Labels map[string]string
+	Driver SecretDriverSpec
+}
+
+// SecretDriverSpec describes which secret driver backs a secret and its
+// driver-specific options.
+type SecretDriverSpec struct {
+	Name    string
+	Options map[string]string
+}
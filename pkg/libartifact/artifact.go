@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"github.com/containers/image/v5/manifest"
 	"github.com/containers/image/v5/oci/layout"
-	"github.com/containers/podman/v5/pkg/libartifact/types"
+	"github.com/containers/podman/v4/pkg/libartifact/types"
 )
 
 type Artifact struct {
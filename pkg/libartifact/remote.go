@@ -0,0 +1,424 @@
+package libartifact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/containers/common/pkg/encryption"
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/signature"
+	"github.com/containers/image/v5/signature/sigstore"
+	"github.com/containers/image/v5/types"
+	"github.com/containers/ocicrypt"
+	encconfig "github.com/containers/ocicrypt/config"
+	artifacttypes "github.com/containers/podman/v4/pkg/libartifact/types"
+	"github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// signatureMediaType is the media type recorded for the blob a Push signing
+// operation produces, mirroring the media type cosign uses for detached
+// signature bundles.
+const signatureMediaType = "application/vnd.dev.sigstore.bundle+json"
+
+// RemoteOptions carries the image-style credentials, TLS, encryption, and
+// signing knobs that pkg/domain/entities.ArtifactPullOptions/PushOptions
+// already define, so artifacts authenticate and transfer exactly like
+// images do.
+type RemoteOptions struct {
+	AuthFilePath          string
+	CertDirPath           string
+	InsecureSkipTLSVerify types.OptionalBool
+	Username              string
+	Password              string
+	// EncryptLayers selects, by index into the artifact's layers (not
+	// counting the config blob), which layers Push encrypts. An empty
+	// EncryptLayers together with a non-empty EncryptionKeys encrypts
+	// every layer, matching how image pushes treat the same pairing.
+	EncryptLayers []int
+	// EncryptionKeys are the recipients (x509 certificates, PGP key IDs,
+	// or PKCS#11 key URIs understood by c/ocicrypt) layers are encrypted
+	// for when EncryptLayers applies.
+	EncryptionKeys []string
+	// SignBySigstoreParamFileCLI is the path to a sigstore private-key
+	// parameter file (as produced by `cosign generate-key-pair`); when
+	// set, Push signs the pushed manifest and registers the signature as
+	// an OCI 1.1 referrer of it.
+	SignBySigstoreParamFileCLI string
+	Writer                     io.Writer
+}
+
+func (o RemoteOptions) systemContext() *types.SystemContext {
+	sys := &types.SystemContext{
+		AuthFilePath:                o.AuthFilePath,
+		DockerCertPath:              o.CertDirPath,
+		DockerInsecureSkipTLSVerify: o.InsecureSkipTLSVerify,
+	}
+	if o.Username != "" {
+		sys.DockerAuthConfig = &types.DockerAuthConfig{Username: o.Username, Password: o.Password}
+	}
+	return sys
+}
+
+// shouldEncryptLayer reports whether Push should encrypt the layer at
+// layerIndex (an index into the artifact's layers, not counting the config
+// blob), given that o.EncryptionKeys resolved to a usable EncryptConfig.
+func (o RemoteOptions) shouldEncryptLayer(layerIndex int) bool {
+	if len(o.EncryptLayers) == 0 {
+		return true
+	}
+	for _, idx := range o.EncryptLayers {
+		if idx == layerIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// cryptoConfig resolves o.EncryptionKeys (x509 certificates, PGP key IDs, or
+// PKCS#11 key URIs) into an ocicrypt CryptoConfig, the same way image pushes
+// turn --encryption-key into one. It returns a zero-value CryptoConfig,
+// whose EncryptConfig is nil, when no keys were given.
+func (o RemoteOptions) cryptoConfig() (encconfig.CryptoConfig, error) {
+	if len(o.EncryptionKeys) == 0 {
+		return encconfig.CryptoConfig{}, nil
+	}
+	return encryption.CreateCryptoConfig(o.EncryptionKeys, nil)
+}
+
+// Pull fetches the OCI 1.1 artifact manifest and its blobs from ref (a
+// docker/registry reference such as "quay.io/baude/artifact:single") and
+// stores it locally under name.
+func (s *ArtifactStore) Pull(ctx context.Context, name, ref string, opts RemoteOptions) (*imgspecv1.Descriptor, error) {
+	srcRef, err := docker.ParseReference("//" + ref)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", ref, err)
+	}
+	sys := opts.systemContext()
+	src, err := srcRef.NewImageSource(ctx, sys)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", ref, err)
+	}
+	defer src.Close()
+
+	manifestBytes, _, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching manifest for %s: %w", ref, err)
+	}
+	oci1, err := manifest.OCI1FromManifest(manifestBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not an OCI artifact manifest: %w", ref, err)
+	}
+
+	for _, b := range append([]imgspecv1.Descriptor{oci1.Config}, oci1.Layers...) {
+		rc, _, err := src.GetBlob(ctx, types.BlobInfo{Digest: b.Digest, Size: b.Size}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("fetching blob %s: %w", b.Digest, err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading blob %s: %w", b.Digest, err)
+		}
+		if _, err := s.writeBlob(data, b.MediaType); err != nil {
+			return nil, err
+		}
+		if opts.Writer != nil {
+			fmt.Fprintf(opts.Writer, "Copying blob %s\n", b.Digest.Encoded()[:12])
+		}
+	}
+
+	manifestDesc, err := s.writeBlob(manifestBytes, imgspecv1.MediaTypeImageManifest)
+	if err != nil {
+		return nil, err
+	}
+	manifestDesc.Annotations = map[string]string{artifacttypes.AnnotatedName: name}
+	manifestDesc.ArtifactType = oci1.ArtifactType
+
+	index, err := s.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	index.Manifests = append(index.Manifests, manifestDesc)
+	if err := s.writeIndex(index); err != nil {
+		return nil, err
+	}
+	return &manifestDesc, nil
+}
+
+// Push uploads the artifact registered under name to ref, encrypting layers
+// opts selects and, if opts.SignBySigstoreParamFileCLI is set, signing the
+// pushed manifest the same way an image push would.
+func (s *ArtifactStore) Push(ctx context.Context, name, ref string, opts RemoteOptions) error {
+	artifact, err := s.Inspect(name)
+	if err != nil {
+		return err
+	}
+	oci1 := artifact.Manifests[0]
+
+	destRef, err := docker.ParseReference("//" + ref)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", ref, err)
+	}
+	sys := opts.systemContext()
+	dest, err := destRef.NewImageDestination(ctx, sys)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", ref, err)
+	}
+	defer dest.Close()
+
+	ccc, err := opts.cryptoConfig()
+	if err != nil {
+		return fmt.Errorf("resolving encryption keys: %w", err)
+	}
+
+	config, err := s.pushBlob(ctx, dest, oci1.Config, opts, nil)
+	if err != nil {
+		return err
+	}
+	oci1.Config = config
+
+	for i := range oci1.Layers {
+		var ecc *encconfig.EncryptConfig
+		if ccc.EncryptConfig != nil && opts.shouldEncryptLayer(i) {
+			ecc = ccc.EncryptConfig
+		}
+		layer, err := s.pushBlob(ctx, dest, oci1.Layers[i], opts, ecc)
+		if err != nil {
+			return err
+		}
+		oci1.Layers[i] = layer
+	}
+
+	manifestBytes, err := oci1.Serialize()
+	if err != nil {
+		return err
+	}
+	if err := dest.PutManifest(ctx, manifestBytes, nil); err != nil {
+		return fmt.Errorf("pushing manifest: %w", err)
+	}
+	if err := dest.Commit(ctx, nil); err != nil {
+		return fmt.Errorf("committing %s: %w", ref, err)
+	}
+
+	manifestDesc := imgspecv1.Descriptor{
+		MediaType: imgspecv1.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(manifestBytes),
+		Size:      int64(len(manifestBytes)),
+	}
+	if oci1.Subject != nil {
+		if err := s.registerReferrer(ctx, ref, manifestDesc, oci1.Subject.Digest); err != nil {
+			return fmt.Errorf("registering referrer: %w", err)
+		}
+	}
+	if opts.SignBySigstoreParamFileCLI != "" {
+		if err := opts.sign(ctx, s, ref, manifestDesc); err != nil {
+			return fmt.Errorf("signing %s: %w", ref, err)
+		}
+	}
+	return nil
+}
+
+// pushBlob uploads the blob described by b, encrypting it first with ecc
+// when non-nil, and returns the descriptor Push should record for it in
+// place of b (unchanged unless encryption rewrote the digest, size,
+// mediaType, or annotations).
+func (s *ArtifactStore) pushBlob(ctx context.Context, dest types.ImageDestination, b imgspecv1.Descriptor, opts RemoteOptions, ecc *encconfig.EncryptConfig) (imgspecv1.Descriptor, error) {
+	data, err := s.readBlob(b.Digest)
+	if err != nil {
+		return b, err
+	}
+
+	desc := b
+	if ecc != nil {
+		reader, finalize, err := ocicrypt.EncryptLayer(ecc, bytes.NewReader(data), b)
+		if err != nil {
+			return b, fmt.Errorf("encrypting layer %s: %w", b.Digest, err)
+		}
+		if data, err = io.ReadAll(reader); err != nil {
+			return b, fmt.Errorf("reading encrypted layer %s: %w", b.Digest, err)
+		}
+		annotations, err := finalize()
+		if err != nil {
+			return b, fmt.Errorf("finalizing encrypted layer %s: %w", b.Digest, err)
+		}
+		desc.MediaType += "+encrypted"
+		desc.Digest = digest.FromBytes(data)
+		desc.Size = int64(len(data))
+		desc.Annotations = mergeAnnotations(desc.Annotations, annotations)
+	}
+
+	info := types.BlobInfo{Digest: desc.Digest, Size: desc.Size, MediaType: desc.MediaType}
+	if _, err := dest.PutBlob(ctx, bytes.NewReader(data), info, nil, false); err != nil {
+		return b, fmt.Errorf("pushing blob %s: %w", desc.Digest, err)
+	}
+	if opts.Writer != nil {
+		verb := "Copying"
+		if ecc != nil {
+			verb = "Encrypting and copying"
+		}
+		fmt.Fprintf(opts.Writer, "%s blob %s\n", verb, desc.Digest.Encoded()[:12])
+	}
+	return desc, nil
+}
+
+// mergeAnnotations returns dst with every key in src set, allocating dst if
+// it was nil. src is never mutated.
+func mergeAnnotations(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// sign signs manifestDesc with the sigstore key at
+// o.SignBySigstoreParamFileCLI and registers the signature blob as an OCI
+// 1.1 referrer of it, the same way `cosign attach signature` publishes a
+// signature alongside the image it covers.
+func (o RemoteOptions) sign(ctx context.Context, s *ArtifactStore, ref string, manifestDesc imgspecv1.Descriptor) error {
+	signer, err := sigstore.NewSigner(sigstore.WithPrivateKeyFile(o.SignBySigstoreParamFileCLI, nil))
+	if err != nil {
+		return fmt.Errorf("loading sigstore key %s: %w", o.SignBySigstoreParamFileCLI, err)
+	}
+	defer signer.Close()
+
+	manifestBytes, err := s.readBlob(manifestDesc.Digest)
+	if err != nil {
+		return err
+	}
+	repo, _, _ := strings.Cut(ref, ":")
+	dockerReference := repo + "@" + manifestDesc.Digest.String()
+
+	sig, err := signature.Sign(signer, manifestBytes, dockerReference)
+	if err != nil {
+		return fmt.Errorf("signing manifest: %w", err)
+	}
+	sigBytes, err := sig.UntrustedSignatureData()
+	if err != nil {
+		return fmt.Errorf("encoding signature: %w", err)
+	}
+
+	sigDesc, err := s.writeBlob(sigBytes, signatureMediaType)
+	if err != nil {
+		return err
+	}
+	return s.registerReferrer(ctx, ref, sigDesc, manifestDesc.Digest)
+}
+
+// readBlob reads a previously stored blob back out of the local layout.
+func (s *ArtifactStore) readBlob(dgst digest.Digest) ([]byte, error) {
+	return s.readBlobByHex(dgst.Encoded())
+}
+
+// registerReferrer makes manifestDesc discoverable as a referrer of
+// subjectDigest. It first checks whether the registry hosting ref already
+// computes the OCI 1.1 referrers list for subjectDigest (GET
+// /v2/<name>/referrers/<digest>, 200 OK); if so, there is nothing more to
+// do. Otherwise it falls back to the OCI 1.1 "Referrers Tag Schema": the
+// referrers index for subjectDigest lives at the tag "<alg>-<hex>", so this
+// fetches that index (if any), appends manifestDesc, and pushes it back.
+func (s *ArtifactStore) registerReferrer(ctx context.Context, ref string, manifestDesc imgspecv1.Descriptor, subjectDigest digest.Digest) error {
+	repo, _, _ := strings.Cut(ref, ":")
+
+	supported, err := registryServesReferrersAPI(ctx, repo, subjectDigest)
+	if err != nil {
+		return err
+	}
+	if supported {
+		return nil
+	}
+
+	fallbackTag := strings.ReplaceAll(subjectDigest.String(), ":", "-")
+	fallbackRef, err := docker.ParseReference("//" + repo + ":" + fallbackTag)
+	if err != nil {
+		return fmt.Errorf("parsing fallback referrers tag %s: %w", fallbackTag, err)
+	}
+
+	index, err := fetchReferrersIndex(ctx, fallbackRef)
+	if err != nil {
+		return err
+	}
+	index.Manifests = append(index.Manifests, manifestDesc)
+
+	dest, err := fallbackRef.NewImageDestination(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("opening referrers tag %s: %w", fallbackTag, err)
+	}
+	defer dest.Close()
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	if err := dest.PutManifest(ctx, data, nil); err != nil {
+		return fmt.Errorf("pushing referrers index %s: %w", fallbackTag, err)
+	}
+	return dest.Commit(ctx, nil)
+}
+
+// registryServesReferrersAPI reports whether the registry hosting repo
+// implements the OCI 1.1 referrers API (GET /v2/<repo>/referrers/<digest>
+// returning 200) for subjectDigest, in which case clients should rely on
+// the registry rather than the tag-schema fallback.
+func registryServesReferrersAPI(ctx context.Context, repo string, subjectDigest digest.Digest) (bool, error) {
+	url := fmt.Sprintf("https://%s/v2/%s/referrers/%s", repoHost(repo), repoPath(repo), subjectDigest.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("building referrers probe request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("probing referrers API on %s: %w", repoHost(repo), err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode == http.StatusOK, nil
+}
+
+// fetchReferrersIndex reads the existing referrers index manifest at ref,
+// returning a fresh empty index if none has been pushed there yet.
+func fetchReferrersIndex(ctx context.Context, ref types.ImageReference) (imgspecv1.Index, error) {
+	index := imgspecv1.Index{
+		MediaType:     imgspecv1.MediaTypeImageIndex,
+		SchemaVersion: 2,
+	}
+
+	src, err := ref.NewImageSource(ctx, nil)
+	if err != nil {
+		return index, nil //nolint:nilerr // no existing referrers index is the common case
+	}
+	defer src.Close()
+
+	manifestBytes, _, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return index, nil //nolint:nilerr // no existing referrers index is the common case
+	}
+	if err := json.Unmarshal(manifestBytes, &index); err != nil {
+		return index, fmt.Errorf("parsing existing referrers index: %w", err)
+	}
+	return index, nil
+}
+
+func repoHost(ref string) string {
+	host, _, _ := strings.Cut(ref, "/")
+	return host
+}
+
+func repoPath(ref string) string {
+	_, path, found := strings.Cut(ref, "/")
+	if !found {
+		return ref
+	}
+	return path
+}
@@ -0,0 +1,236 @@
+package libartifact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/oci/layout"
+	artifacttypes "github.com/containers/podman/v4/pkg/libartifact/types"
+	"github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ociManifest mirrors the OCI 1.1 image-manifest fields libartifact needs to
+// set (artifactType, subject) that may not yet be exposed by every vendored
+// version of manifest.OCI1. It is only used to build the bytes we write to
+// the local store; reading is done through manifest.OCI1FromManifest so the
+// rest of the codebase keeps working with the upstream type.
+type ociManifest struct {
+	SchemaVersion int                    `json:"schemaVersion"`
+	MediaType     string                 `json:"mediaType"`
+	ArtifactType  string                 `json:"artifactType,omitempty"`
+	Config        imgspecv1.Descriptor   `json:"config"`
+	Layers        []imgspecv1.Descriptor `json:"layers"`
+	Subject       *imgspecv1.Descriptor  `json:"subject,omitempty"`
+	Annotations   map[string]string      `json:"annotations,omitempty"`
+}
+
+// ArtifactStore is a local directory laid out as a standard OCI image
+// layout (oci-layout + index.json + blobs/<alg>/<hex>) in which every entry
+// in the top-level index is one artifact, keyed by the
+// artifacttypes.AnnotatedName annotation on its manifest descriptor.
+type ArtifactStore struct {
+	dir string
+}
+
+// NewArtifactStore opens (creating if necessary) the OCI image layout at dir.
+func NewArtifactStore(dir string) (*ArtifactStore, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "blobs", "sha256"), 0o755); err != nil {
+		return nil, fmt.Errorf("creating artifact store %q: %w", dir, err)
+	}
+	store := &ArtifactStore{dir: dir}
+	if err := store.ensureLayout(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *ArtifactStore) ensureLayout() error {
+	layoutFile := filepath.Join(s.dir, imgspecv1.ImageLayoutFile)
+	if _, err := os.Stat(layoutFile); err == nil {
+		return nil
+	}
+	ociLayout := imgspecv1.ImageLayout{Version: imgspecv1.ImageLayoutVersion}
+	data, err := json.Marshal(ociLayout)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(layoutFile, data, 0o644); err != nil {
+		return err
+	}
+
+	indexFile := filepath.Join(s.dir, "index.json")
+	if _, err := os.Stat(indexFile); err == nil {
+		return nil
+	}
+	index := imgspecv1.Index{
+		MediaType: imgspecv1.MediaTypeImageIndex,
+	}
+	index.SchemaVersion = 2
+	return s.writeIndex(index)
+}
+
+func (s *ArtifactStore) readIndex() (imgspecv1.Index, error) {
+	var index imgspecv1.Index
+	data, err := os.ReadFile(filepath.Join(s.dir, "index.json"))
+	if err != nil {
+		return index, err
+	}
+	err = json.Unmarshal(data, &index)
+	return index, err
+}
+
+func (s *ArtifactStore) writeIndex(index imgspecv1.Index) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.dir, "index.json"), data, 0o644)
+}
+
+// writeBlob writes data under blobs/sha256/<hex> and returns its descriptor.
+func (s *ArtifactStore) writeBlob(data []byte, mediaType string) (imgspecv1.Descriptor, error) {
+	sum := sha256.Sum256(data)
+	hexDigest := hex.EncodeToString(sum[:])
+	path := filepath.Join(s.dir, "blobs", "sha256", hexDigest)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return imgspecv1.Descriptor{}, err
+	}
+	return imgspecv1.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.Digest("sha256:" + hexDigest),
+		Size:      int64(len(data)),
+	}, nil
+}
+
+// readBlobByHex reads a blob back out of blobs/sha256/<hex>.
+func (s *ArtifactStore) readBlobByHex(hexDigest string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(s.dir, "blobs", "sha256", hexDigest))
+}
+
+// List returns every artifact currently recorded in the store's index.
+func (s *ArtifactStore) List() (ArtifactList, error) {
+	index, err := s.readIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	var artifacts ArtifactList
+	for _, desc := range index.Manifests {
+		blob, err := s.readBlobByHex(desc.Digest.Encoded())
+		if err != nil {
+			return nil, err
+		}
+		oci1, err := manifest.OCI1FromManifest(blob)
+		if err != nil {
+			return nil, fmt.Errorf("parsing manifest for %s: %w", desc.Annotations[artifacttypes.AnnotatedName], err)
+		}
+		artifacts = append(artifacts, &Artifact{
+			List:      layout.ListResult{ManifestDescriptor: desc},
+			Manifests: []manifest.OCI1{*oci1},
+		})
+	}
+	return artifacts, nil
+}
+
+// Inspect returns the artifact registered under name.
+func (s *ArtifactStore) Inspect(name string) (*Artifact, error) {
+	artifacts, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	return artifacts.GetByName(name)
+}
+
+// Remove deletes the index entry for name. The underlying blobs are left in
+// place; a future `artifact prune` can reclaim anything unreferenced.
+func (s *ArtifactStore) Remove(name string) error {
+	index, err := s.readIndex()
+	if err != nil {
+		return err
+	}
+	kept := index.Manifests[:0]
+	found := false
+	for _, desc := range index.Manifests {
+		if desc.Annotations[artifacttypes.AnnotatedName] == name {
+			found = true
+			continue
+		}
+		kept = append(kept, desc)
+	}
+	if !found {
+		return fmt.Errorf("no artifact found with name %s", name)
+	}
+	index.Manifests = kept
+	return s.writeIndex(index)
+}
+
+// AddBlob is one file to be stored as an artifact layer, with the media type
+// the caller wants recorded for it.
+type AddBlob struct {
+	Path      string
+	MediaType string
+}
+
+// Add builds an OCI 1.1 artifact manifest (one layer per blob, with
+// artifactType set from artifactType) out of blobs, stores it in the local
+// layout, and records it in the index under name.
+func (s *ArtifactStore) Add(name, artifactType string, blobs []AddBlob) (*imgspecv1.Descriptor, error) {
+	if artifactType == "" {
+		artifactType = artifacttypes.DefaultArtifactType
+	}
+
+	layers := make([]imgspecv1.Descriptor, 0, len(blobs))
+	for _, b := range blobs {
+		data, err := os.ReadFile(b.Path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", b.Path, err)
+		}
+		desc, err := s.writeBlob(data, b.MediaType)
+		if err != nil {
+			return nil, fmt.Errorf("storing %s: %w", b.Path, err)
+		}
+		layers = append(layers, desc)
+	}
+
+	// Artifact manifests use the "empty" config descriptor per the OCI
+	// 1.1 guidance for configless artifacts.
+	emptyConfig, err := s.writeBlob([]byte("{}"), imgspecv1.MediaTypeImageConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	m := ociManifest{
+		SchemaVersion: 2,
+		MediaType:     imgspecv1.MediaTypeImageManifest,
+		ArtifactType:  artifactType,
+		Config:        emptyConfig,
+		Layers:        layers,
+	}
+	manifestBytes, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	manifestDesc, err := s.writeBlob(manifestBytes, imgspecv1.MediaTypeImageManifest)
+	if err != nil {
+		return nil, err
+	}
+	manifestDesc.Annotations = map[string]string{artifacttypes.AnnotatedName: name}
+	manifestDesc.ArtifactType = artifactType
+
+	index, err := s.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	index.Manifests = append(index.Manifests, manifestDesc)
+	if err := s.writeIndex(index); err != nil {
+		return nil, err
+	}
+
+	return &manifestDesc, nil
+}
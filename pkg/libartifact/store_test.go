@@ -0,0 +1,59 @@
+package libartifact
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	artifacttypes "github.com/containers/podman/v4/pkg/libartifact/types"
+)
+
+func TestArtifactStoreAddInspectRemoveRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewArtifactStore(dir)
+	if err != nil {
+		t.Fatalf("NewArtifactStore() error = %v", err)
+	}
+
+	blobPath := filepath.Join(dir, "layer.txt")
+	const blobContent = "hello artifact"
+	if err := os.WriteFile(blobPath, []byte(blobContent), 0o644); err != nil {
+		t.Fatalf("writing fixture blob: %v", err)
+	}
+
+	const name = "localhost/test:latest"
+	desc, err := store.Add(name, "", []AddBlob{{Path: blobPath, MediaType: "text/plain"}})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if desc.Annotations[artifacttypes.AnnotatedName] != name {
+		t.Errorf("Add() descriptor name annotation = %q, want %q", desc.Annotations[artifacttypes.AnnotatedName], name)
+	}
+
+	artifact, err := store.Inspect(name)
+	if err != nil {
+		t.Fatalf("Inspect() error = %v", err)
+	}
+	if len(artifact.Manifests) != 1 {
+		t.Fatalf("Inspect() Manifests = %d, want 1", len(artifact.Manifests))
+	}
+	layers := artifact.Manifests[0].Layers
+	if len(layers) != 1 {
+		t.Fatalf("Inspect() Layers = %d, want 1", len(layers))
+	}
+
+	data, err := store.readBlobByHex(layers[0].Digest.Encoded())
+	if err != nil {
+		t.Fatalf("readBlobByHex() error = %v", err)
+	}
+	if string(data) != blobContent {
+		t.Errorf("readBlobByHex() = %q, want %q", data, blobContent)
+	}
+
+	if err := store.Remove(name); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := store.Inspect(name); err == nil {
+		t.Fatal("Inspect() after Remove() = nil error, want not found")
+	}
+}
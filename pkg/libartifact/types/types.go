@@ -0,0 +1,13 @@
+package types
+
+const (
+	// AnnotatedName is the OCI annotation key libartifact uses to stash the
+	// caller-facing name of an artifact (e.g. "quay.io/baude/artifact:single")
+	// on its manifest descriptor, since the local oci layout index otherwise
+	// has no notion of a human-readable name.
+	AnnotatedName = "org.opencontainers.image.ref.name"
+
+	// DefaultArtifactType is used for Add() calls that do not specify an
+	// ArtifactAddoptions.ArtifactType.
+	DefaultArtifactType = "application/vnd.unknown.artifact.v1"
+)
@@ -0,0 +1,25 @@
+package secrets
+
+// Driver is the interface a secrets backend must implement to store (or, for
+// external/passthrough backends, merely resolve) secret payloads on behalf of
+// the secrets manager. Podman ships a handful of in-process drivers (file,
+// shell-exec) as well as the socket driver in ./socket, which proxies every
+// call to an out-of-process plugin so that vendor-specific backends such as
+// Vault, AWS Secrets Manager, or sops never need to be taught to Podman
+// itself. A Driver only knows about single, unversioned ids; Manager wraps
+// one to add rotation and rollback addressing.
+type Driver interface {
+	// List returns the ids of all secrets known to the driver.
+	List() ([]string, error)
+	// Lookup returns the payload for id. External drivers are expected to
+	// hit the backing store on every call rather than caching, since the
+	// whole point of an external secret is that its value can rotate out
+	// from under Podman.
+	Lookup(id string) ([]byte, error)
+	// Store persists data under id. External/passthrough drivers that
+	// only resolve references, and never take ownership of the payload,
+	// should return an error here rather than silently discarding data.
+	Store(id string, data []byte) error
+	// Delete removes id from the driver.
+	Delete(id string) error
+}
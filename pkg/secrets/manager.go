@@ -0,0 +1,188 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Manager layers named, versioned secrets on top of a Driver, which only
+// knows about opaque ids. Each rotation is stored under its own driver id
+// (name@v<N>) so a caller can still Lookup an old payload directly by that
+// id for rollback; Manager tracks which version is current, and the
+// expiration/immutability options set on it, in a small pointer record at
+// name@current.
+type Manager struct {
+	driver Driver
+}
+
+// NewManager wraps driver with version tracking.
+func NewManager(driver Driver) *Manager {
+	return &Manager{driver: driver}
+}
+
+// StoreOptions are the expiration/immutability knobs bindings/secrets.
+// CreateOptions exposes; Manager actually enforces them instead of merely
+// recording them.
+type StoreOptions struct {
+	// ExpiresAt, if set, is when Lookup starts refusing this secret and
+	// Prune removes it.
+	ExpiresAt *time.Time
+	// Immutable, if true, causes Rotate to refuse to replace this
+	// secret's payload.
+	Immutable bool
+}
+
+// pointer is the record Manager stores at name@current to track which
+// rotation is the live one and the StoreOptions it was created with.
+type pointer struct {
+	Version   int        `json:"version"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	Immutable bool       `json:"immutable,omitempty"`
+}
+
+func versionedID(name string, version int) string {
+	return fmt.Sprintf("%s@v%d", name, version)
+}
+
+func pointerID(name string) string {
+	return name + "@current"
+}
+
+// splitVersioned parses the "name@v<N>" rollback-addressing form, returning
+// ok=false for a bare name.
+func splitVersioned(nameOrID string) (name string, version int, ok bool) {
+	idx := strings.LastIndex(nameOrID, "@v")
+	if idx < 0 {
+		return "", 0, false
+	}
+	version, err := strconv.Atoi(nameOrID[idx+2:])
+	if err != nil {
+		return "", 0, false
+	}
+	return nameOrID[:idx], version, true
+}
+
+// currentPointer returns the pointer record for name, or a zero pointer
+// (Version 0) if name has never been stored through this Manager.
+func (m *Manager) currentPointer(name string) (pointer, error) {
+	data, err := m.driver.Lookup(pointerID(name))
+	if err != nil {
+		return pointer{}, nil //nolint:nilerr // no rotations yet is the common case for a brand-new secret
+	}
+	var p pointer
+	if err := json.Unmarshal(data, &p); err != nil {
+		return pointer{}, fmt.Errorf("parsing version pointer for %s: %w", name, err)
+	}
+	return p, nil
+}
+
+// Store saves data as version 1 of name, the same as a plain, never-rotated
+// secret.
+func (m *Manager) Store(name string, data []byte, opts StoreOptions) error {
+	return m.storeVersion(name, 1, data, opts)
+}
+
+func (m *Manager) storeVersion(name string, version int, data []byte, opts StoreOptions) error {
+	if err := m.driver.Store(versionedID(name, version), data); err != nil {
+		return err
+	}
+	pointerData, err := json.Marshal(pointer{Version: version, ExpiresAt: opts.ExpiresAt, Immutable: opts.Immutable})
+	if err != nil {
+		return err
+	}
+	return m.driver.Store(pointerID(name), pointerData)
+}
+
+// Rotate stores data as a new version of name and makes it current,
+// returning the version number it was stored under. It refuses to rotate a
+// secret that was created with StoreOptions.Immutable set, and it carries
+// the existing ExpiresAt/Immutable settings forward onto the new version.
+func (m *Manager) Rotate(name string, data []byte) (int, error) {
+	p, err := m.currentPointer(name)
+	if err != nil {
+		return 0, err
+	}
+	if p.Immutable {
+		return 0, fmt.Errorf("secret %s is immutable: cannot rotate", name)
+	}
+	next := p.Version + 1
+	opts := StoreOptions{ExpiresAt: p.ExpiresAt, Immutable: p.Immutable}
+	if err := m.storeVersion(name, next, data, opts); err != nil {
+		return 0, err
+	}
+	return next, nil
+}
+
+// CurrentVersion returns the version Lookup(name) currently resolves to.
+func (m *Manager) CurrentVersion(name string) (int, error) {
+	p, err := m.currentPointer(name)
+	if err != nil {
+		return 0, err
+	}
+	if p.Version == 0 {
+		return 1, nil
+	}
+	return p.Version, nil
+}
+
+// Lookup resolves nameOrID to a payload. A nameOrID of the form "name@v<N>"
+// (the addressing scheme Rotate's version numbers use for rollback) fetches
+// that exact version regardless of which one is current, bypassing
+// expiration, the same way `docker secret inspect` lets you reach an old
+// version that has already expired as the current one. A bare name fetches
+// whatever Rotate last made current, and is refused once that version's
+// ExpiresAt has passed.
+func (m *Manager) Lookup(nameOrID string) ([]byte, error) {
+	if name, version, ok := splitVersioned(nameOrID); ok {
+		return m.driver.Lookup(versionedID(name, version))
+	}
+
+	p, err := m.currentPointer(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	if p.ExpiresAt != nil && time.Now().After(*p.ExpiresAt) {
+		return nil, fmt.Errorf("secret %s expired at %s", nameOrID, p.ExpiresAt.Format(time.RFC3339))
+	}
+	if p.Version == 0 {
+		return m.driver.Lookup(nameOrID)
+	}
+	return m.driver.Lookup(versionedID(nameOrID, p.Version))
+}
+
+// Prune removes every secret Manager is tracking whose ExpiresAt has
+// passed, the enforcement half of `secret prune`. It returns the names of
+// the secrets it removed.
+func (m *Manager) Prune() ([]string, error) {
+	ids, err := m.driver.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var pruned []string
+	for _, id := range ids {
+		if !strings.HasSuffix(id, "@current") {
+			continue
+		}
+		name := strings.TrimSuffix(id, "@current")
+
+		p, err := m.currentPointer(name)
+		if err != nil {
+			return pruned, err
+		}
+		if p.ExpiresAt == nil || !time.Now().After(*p.ExpiresAt) {
+			continue
+		}
+		if err := m.driver.Delete(versionedID(name, p.Version)); err != nil {
+			return pruned, err
+		}
+		if err := m.driver.Delete(pointerID(name)); err != nil {
+			return pruned, err
+		}
+		pruned = append(pruned, name)
+	}
+	return pruned, nil
+}
@@ -0,0 +1,134 @@
+package secrets
+
+import (
+	"testing"
+	"time"
+)
+
+// memDriver is a minimal in-memory Driver for exercising Manager without a
+// real backend.
+type memDriver struct {
+	data map[string][]byte
+}
+
+func newMemDriver() *memDriver {
+	return &memDriver{data: make(map[string][]byte)}
+}
+
+func (d *memDriver) List() ([]string, error) {
+	ids := make([]string, 0, len(d.data))
+	for id := range d.data {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (d *memDriver) Lookup(id string) ([]byte, error) {
+	data, ok := d.data[id]
+	if !ok {
+		return nil, errNotFound(id)
+	}
+	return data, nil
+}
+
+func (d *memDriver) Store(id string, data []byte) error {
+	d.data[id] = data
+	return nil
+}
+
+func (d *memDriver) Delete(id string) error {
+	delete(d.data, id)
+	return nil
+}
+
+type errNotFound string
+
+func (e errNotFound) Error() string { return "no such secret: " + string(e) }
+
+func TestManagerRotateAndLookup(t *testing.T) {
+	m := NewManager(newMemDriver())
+
+	if err := m.Store("db-password", []byte("v1-secret"), StoreOptions{}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if version, err := m.CurrentVersion("db-password"); err != nil || version != 1 {
+		t.Fatalf("CurrentVersion() = (%d, %v), want (1, nil)", version, err)
+	}
+
+	version, err := m.Rotate("db-password", []byte("v2-secret"))
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if version != 2 {
+		t.Fatalf("Rotate() version = %d, want 2", version)
+	}
+
+	data, err := m.Lookup("db-password")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if string(data) != "v2-secret" {
+		t.Errorf("Lookup() = %q, want %q", data, "v2-secret")
+	}
+
+	old, err := m.Lookup("db-password@v1")
+	if err != nil {
+		t.Fatalf("Lookup(rollback) error = %v", err)
+	}
+	if string(old) != "v1-secret" {
+		t.Errorf("Lookup(rollback) = %q, want %q", old, "v1-secret")
+	}
+}
+
+func TestManagerLookupNeverStored(t *testing.T) {
+	m := NewManager(newMemDriver())
+	if _, err := m.Lookup("missing"); err == nil {
+		t.Fatal("Lookup() of a never-stored name = nil error, want not found")
+	}
+}
+
+func TestManagerRotateImmutable(t *testing.T) {
+	m := NewManager(newMemDriver())
+	if err := m.Store("locked", []byte("v1"), StoreOptions{Immutable: true}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if _, err := m.Rotate("locked", []byte("v2")); err == nil {
+		t.Fatal("Rotate() of an immutable secret = nil error, want an error")
+	}
+}
+
+func TestManagerLookupExpired(t *testing.T) {
+	m := NewManager(newMemDriver())
+	expiresAt := time.Now().Add(-time.Minute)
+	if err := m.Store("stale", []byte("v1"), StoreOptions{ExpiresAt: &expiresAt}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if _, err := m.Lookup("stale"); err == nil {
+		t.Fatal("Lookup() of an expired secret = nil error, want an error")
+	}
+	if _, err := m.Lookup("stale@v1"); err != nil {
+		t.Fatalf("Lookup(rollback) of an expired version error = %v, want nil", err)
+	}
+}
+
+func TestManagerPrune(t *testing.T) {
+	m := NewManager(newMemDriver())
+	expiresAt := time.Now().Add(-time.Minute)
+	if err := m.Store("stale", []byte("v1"), StoreOptions{ExpiresAt: &expiresAt}); err != nil {
+		t.Fatalf("Store(stale) error = %v", err)
+	}
+	if err := m.Store("fresh", []byte("v1"), StoreOptions{}); err != nil {
+		t.Fatalf("Store(fresh) error = %v", err)
+	}
+
+	pruned, err := m.Prune()
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if len(pruned) != 1 || pruned[0] != "stale" {
+		t.Fatalf("Prune() = %v, want [stale]", pruned)
+	}
+	if _, err := m.Lookup("fresh"); err != nil {
+		t.Errorf("Lookup(fresh) after Prune() error = %v, want nil", err)
+	}
+}
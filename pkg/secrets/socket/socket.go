@@ -0,0 +1,130 @@
+// Package socket implements a secrets.Driver that proxies every call to an
+// out-of-process plugin over a Unix socket, using newline-delimited JSON
+// framing. It backs Podman's external/passthrough secrets: the payload never
+// lives in Podman's own database, only a reference to it does, and every
+// Lookup is forwarded live to the plugin so the value can be rotated by
+// whatever owns it (Vault, AWS Secrets Manager, sops, ...) without Podman
+// knowing anything about that vendor.
+package socket
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// defaultTimeout is used when the driver options omit "timeout=".
+	defaultTimeout = 5 * time.Second
+
+	opList   = "list"
+	opLookup = "lookup"
+	opStore  = "store"
+	opDelete = "delete"
+)
+
+// request is one JSON-framed line sent to the plugin.
+type request struct {
+	Op   string `json:"op"`
+	ID   string `json:"id,omitempty"`
+	Data []byte `json:"data,omitempty"`
+}
+
+// response is one JSON-framed line read back from the plugin.
+type response struct {
+	Error string   `json:"error,omitempty"`
+	Data  []byte   `json:"data,omitempty"`
+	IDs   []string `json:"ids,omitempty"`
+}
+
+// Driver talks to a single external secrets plugin over a Unix socket.
+//
+// DriverOpts reserves two keys for configuring it:
+//
+//	socket=  - path to the plugin's listening Unix socket (required)
+//	timeout= - a time.ParseDuration string for the per-call deadline
+//	           (optional, defaults to 5s)
+type Driver struct {
+	socketPath string
+	timeout    time.Duration
+}
+
+// New builds a Driver from the secret's DriverOpts, as recorded on
+// secrets.CreateOptions.DriverOpts.
+func New(opts map[string]string) (*Driver, error) {
+	socketPath, ok := opts["socket"]
+	if !ok || socketPath == "" {
+		return nil, fmt.Errorf("socket driver: missing required driver option %q", "socket")
+	}
+
+	timeout := defaultTimeout
+	if raw, ok := opts["timeout"]; ok && raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("socket driver: invalid driver option %q: %w", "timeout", err)
+		}
+		timeout = parsed
+	}
+
+	return &Driver{socketPath: socketPath, timeout: timeout}, nil
+}
+
+func (d *Driver) call(req request) (*response, error) {
+	conn, err := net.DialTimeout("unix", d.socketPath, d.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("socket driver: dialing %s: %w", d.socketPath, err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(d.timeout)); err != nil {
+		return nil, fmt.Errorf("socket driver: setting deadline: %w", err)
+	}
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, fmt.Errorf("socket driver: writing request: %w", err)
+	}
+
+	var resp response
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("socket driver: reading response: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("socket driver: plugin error: %s", resp.Error)
+	}
+	return &resp, nil
+}
+
+// List returns the ids the plugin currently knows about.
+func (d *Driver) List() ([]string, error) {
+	resp, err := d.call(request{Op: opList})
+	if err != nil {
+		return nil, err
+	}
+	return resp.IDs, nil
+}
+
+// Lookup asks the plugin to resolve id to its current payload. This always
+// round-trips to the plugin; it never serves a cached value.
+func (d *Driver) Lookup(id string) ([]byte, error) {
+	resp, err := d.call(request{Op: opLookup, ID: id})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// Store forwards data under id to the plugin. Whether the payload is kept is
+// up to the plugin; a plugin that does not take ownership of payloads
+// answers with an error, which Store returns.
+func (d *Driver) Store(id string, data []byte) error {
+	_, err := d.call(request{Op: opStore, ID: id, Data: data})
+	return err
+}
+
+// Delete asks the plugin to forget id.
+func (d *Driver) Delete(id string) error {
+	_, err := d.call(request{Op: opDelete, ID: id})
+	return err
+}
@@ -0,0 +1,127 @@
+package socket
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+)
+
+// servePlugin accepts exactly one connection on ln, decodes the request
+// frame, hands it to handle, and writes back whatever response handle
+// returns, newline-delimited just like Driver expects.
+func servePlugin(t *testing.T, ln net.Listener, handle func(request) response) {
+	t.Helper()
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Errorf("Accept() error = %v", err)
+		return
+	}
+	defer conn.Close()
+
+	var req request
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&req); err != nil {
+		t.Errorf("plugin: decoding request: %v", err)
+		return
+	}
+	if err := json.NewEncoder(conn).Encode(handle(req)); err != nil {
+		t.Errorf("plugin: encoding response: %v", err)
+	}
+}
+
+func newTestDriver(t *testing.T, handle func(request) response) *Driver {
+	t.Helper()
+	socketPath := filepath.Join(t.TempDir(), "plugin.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go servePlugin(t, ln, handle)
+
+	d, err := New(map[string]string{"socket": socketPath})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return d
+}
+
+func TestDriverListFraming(t *testing.T) {
+	var gotOp string
+	d := newTestDriver(t, func(req request) response {
+		gotOp = req.Op
+		return response{IDs: []string{"one", "two"}}
+	})
+
+	ids, err := d.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if gotOp != opList {
+		t.Errorf("plugin saw op = %q, want %q", gotOp, opList)
+	}
+	if len(ids) != 2 || ids[0] != "one" || ids[1] != "two" {
+		t.Errorf("List() = %v, want [one two]", ids)
+	}
+}
+
+func TestDriverLookupFraming(t *testing.T) {
+	var gotReq request
+	d := newTestDriver(t, func(req request) response {
+		gotReq = req
+		return response{Data: []byte("payload")}
+	})
+
+	data, err := d.Lookup("db-password")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if gotReq.Op != opLookup || gotReq.ID != "db-password" {
+		t.Errorf("plugin saw request = %+v, want op=%q id=%q", gotReq, opLookup, "db-password")
+	}
+	if string(data) != "payload" {
+		t.Errorf("Lookup() = %q, want %q", data, "payload")
+	}
+}
+
+func TestDriverStoreFraming(t *testing.T) {
+	var gotReq request
+	d := newTestDriver(t, func(req request) response {
+		gotReq = req
+		return response{}
+	})
+
+	if err := d.Store("db-password", []byte("payload")); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if gotReq.Op != opStore || gotReq.ID != "db-password" || string(gotReq.Data) != "payload" {
+		t.Errorf("plugin saw request = %+v, want op=%q id=%q data=%q", gotReq, opStore, "db-password", "payload")
+	}
+}
+
+func TestDriverDeleteFraming(t *testing.T) {
+	var gotReq request
+	d := newTestDriver(t, func(req request) response {
+		gotReq = req
+		return response{}
+	})
+
+	if err := d.Delete("db-password"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if gotReq.Op != opDelete || gotReq.ID != "db-password" {
+		t.Errorf("plugin saw request = %+v, want op=%q id=%q", gotReq, opDelete, "db-password")
+	}
+}
+
+func TestDriverCallReturnsPluginError(t *testing.T) {
+	d := newTestDriver(t, func(req request) response {
+		return response{Error: "no such secret"}
+	})
+
+	if _, err := d.Lookup("missing"); err == nil {
+		t.Fatal("Lookup() with a plugin error response = nil error, want an error")
+	}
+}